@@ -0,0 +1,112 @@
+package telemetry
+
+import (
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// SamplerKind identifies a trace sampler strategy, mirroring the Jaeger-style names accepted by
+// OTEL_TRACES_SAMPLER.
+type SamplerKind string
+
+const (
+	SamplerAlwaysOn      SamplerKind = "always_on"
+	SamplerAlwaysOff     SamplerKind = "always_off"
+	SamplerTraceIDRatio  SamplerKind = "traceidratio"
+	SamplerConst         SamplerKind = "const"
+	SamplerProbabilistic SamplerKind = "probabilistic"
+	SamplerRateLimiting  SamplerKind = "ratelimiting"
+	SamplerRemote        SamplerKind = "remote"
+)
+
+// ParentBasedConfig configures per-case sampling overrides for a ParentBased sampler, mirroring
+// the options accepted by go.opentelemetry.io/otel/sdk/trace.ParentBased. Any field left nil
+// falls back to the sdk's own default for that case.
+type ParentBasedConfig struct {
+	LocalParentSampled     sdktrace.Sampler
+	LocalParentNotSampled  sdktrace.Sampler
+	RemoteParentSampled    sdktrace.Sampler
+	RemoteParentNotSampled sdktrace.Sampler
+}
+
+// SamplerConfig is the programmatic equivalent of OTEL_TRACES_SAMPLER / OTEL_TRACES_SAMPLER_ARG,
+// letting callers configure sampling without going through the environment.
+type SamplerConfig struct {
+	// Kind selects which sampler strategy to build. Required.
+	Kind SamplerKind
+
+	// ConstDecision is used when Kind is SamplerConst: true always samples, false never does.
+	ConstDecision bool
+	// Ratio is used when Kind is SamplerTraceIDRatio or SamplerProbabilistic, and must be in
+	// [0.0, 1.0].
+	Ratio float64
+	// RateLimitPerSecond is used when Kind is SamplerRateLimiting.
+	RateLimitPerSecond float64
+	// Remote is used when Kind is SamplerRemote.
+	Remote *RemoteSamplerConfig
+
+	// ParentBased, if non-nil, wraps the sampler selected above in a ParentBased sampler using
+	// the given per-case overrides.
+	ParentBased *ParentBasedConfig
+}
+
+// SamplerFromConfig builds a sdktrace.Sampler from cfg, applying the same semantics as the
+// OTEL_TRACES_SAMPLER/OTEL_TRACES_SAMPLER_ARG env vars handled by samplerFromEnv. It lets callers
+// of EnableOTELTracingWithSampler opt into sampler kinds (remote, ratelimiting, ...) that have no
+// env-only equivalent configuration, such as a caller-provided InitialSampler for the remote
+// sampler.
+func SamplerFromConfig(cfg SamplerConfig) (sdktrace.Sampler, error) {
+	base, err := samplerFromConfigKind(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ParentBased == nil {
+		return base, nil
+	}
+	parentBased := sdktrace.ParentBased(base, parentBasedOptions(*cfg.ParentBased)...)
+	if closer, ok := closerOf(base); ok {
+		return closingSampler{Sampler: parentBased, closer: closer}, nil
+	}
+	return parentBased, nil
+}
+
+func samplerFromConfigKind(cfg SamplerConfig) (sdktrace.Sampler, error) {
+	switch cfg.Kind {
+	case SamplerAlwaysOn:
+		return sdktrace.AlwaysSample(), nil
+	case SamplerAlwaysOff:
+		return sdktrace.NeverSample(), nil
+	case SamplerTraceIDRatio, SamplerProbabilistic:
+		return validateTraceIDRatio(cfg.Ratio)
+	case SamplerConst:
+		if cfg.ConstDecision {
+			return sdktrace.AlwaysSample(), nil
+		}
+		return sdktrace.NeverSample(), nil
+	case SamplerRateLimiting:
+		return NewRateLimitingSampler(cfg.RateLimitPerSecond), nil
+	case SamplerRemote:
+		if cfg.Remote == nil {
+			return nil, errUnsupportedSampler(string(SamplerRemote) + ": missing RemoteSamplerConfig")
+		}
+		return newRemoteSampler(*cfg.Remote)
+	default:
+		return nil, errUnsupportedSampler(string(cfg.Kind))
+	}
+}
+
+func parentBasedOptions(cfg ParentBasedConfig) []sdktrace.ParentBasedSamplerOption {
+	var opts []sdktrace.ParentBasedSamplerOption
+	if cfg.LocalParentSampled != nil {
+		opts = append(opts, sdktrace.WithLocalParentSampled(cfg.LocalParentSampled))
+	}
+	if cfg.LocalParentNotSampled != nil {
+		opts = append(opts, sdktrace.WithLocalParentNotSampled(cfg.LocalParentNotSampled))
+	}
+	if cfg.RemoteParentSampled != nil {
+		opts = append(opts, sdktrace.WithRemoteParentSampled(cfg.RemoteParentSampled))
+	}
+	if cfg.RemoteParentNotSampled != nil {
+		opts = append(opts, sdktrace.WithRemoteParentNotSampled(cfg.RemoteParentNotSampled))
+	}
+	return opts
+}
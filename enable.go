@@ -0,0 +1,37 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// EnableOTEL enables the tracing, metrics, and logs pipelines together, sharing opts across all
+// three, and returns a single shutdown func that tears all three down (logs and metrics first,
+// then tracing, so a service's last trace can still observe its own shutdown). The second
+// return value is the Prometheus scrape handler from EnableOTELMetrics, non-nil only when
+// opts.MetricsExporter is MetricsExporterPrometheus.
+func EnableOTEL(ctx context.Context, opts Options) (func(context.Context) error, http.Handler, error) {
+	shutdownTracing := EnableOTELTracingWithOptions(ctx, opts)
+
+	shutdownMetrics, promHandler, err := EnableOTELMetrics(ctx, opts)
+	if err != nil {
+		shutdownTracing(ctx)
+		return nil, nil, err
+	}
+
+	shutdownLogs, err := EnableOTELLogs(ctx, opts)
+	if err != nil {
+		shutdownMetrics(ctx)
+		shutdownTracing(ctx)
+		return nil, nil, err
+	}
+
+	return func(ctx context.Context) error {
+		return errors.Join(
+			shutdownLogs(ctx),
+			shutdownMetrics(ctx),
+			shutdownTracing(ctx),
+		)
+	}, promHandler, nil
+}
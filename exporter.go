@@ -0,0 +1,117 @@
+package telemetry
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"google.golang.org/grpc/credentials"
+)
+
+// newTraceExporter builds an OTLP trace exporter for the transport selected by opts.
+func newTraceExporter(ctx context.Context, opts Options) (*otlptrace.Exporter, error) {
+	switch opts.Transport {
+	case TransportGRPC:
+		clientOpts, err := grpcClientOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+		return otlptrace.New(ctx, otlptracegrpc.NewClient(clientOpts...))
+	case TransportHTTP, "":
+		clientOpts, err := httpClientOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+		return otlptrace.New(ctx, otlptracehttp.NewClient(clientOpts...))
+	default:
+		return nil, fmt.Errorf("telemetry: unsupported transport %q", opts.Transport)
+	}
+}
+
+func httpClientOptions(opts Options) ([]otlptracehttp.Option, error) {
+	var clientOpts []otlptracehttp.Option
+	if opts.Endpoint != "" {
+		clientOpts = append(clientOpts, otlptracehttp.WithEndpoint(opts.Endpoint))
+	}
+	if len(opts.Headers) > 0 {
+		clientOpts = append(clientOpts, otlptracehttp.WithHeaders(opts.Headers))
+	}
+	if opts.Timeout > 0 {
+		clientOpts = append(clientOpts, otlptracehttp.WithTimeout(opts.Timeout))
+	}
+	if opts.Compression == "gzip" {
+		clientOpts = append(clientOpts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+	}
+	if opts.TLS != nil {
+		if opts.TLS.Insecure {
+			clientOpts = append(clientOpts, otlptracehttp.WithInsecure())
+		} else {
+			tlsConfig, err := opts.TLS.build()
+			if err != nil {
+				return nil, err
+			}
+			clientOpts = append(clientOpts, otlptracehttp.WithTLSClientConfig(tlsConfig))
+		}
+	}
+	return clientOpts, nil
+}
+
+func grpcClientOptions(opts Options) ([]otlptracegrpc.Option, error) {
+	var clientOpts []otlptracegrpc.Option
+	if opts.Endpoint != "" {
+		clientOpts = append(clientOpts, otlptracegrpc.WithEndpoint(opts.Endpoint))
+	}
+	if len(opts.Headers) > 0 {
+		clientOpts = append(clientOpts, otlptracegrpc.WithHeaders(opts.Headers))
+	}
+	if opts.Timeout > 0 {
+		clientOpts = append(clientOpts, otlptracegrpc.WithTimeout(opts.Timeout))
+	}
+	if opts.Compression == "gzip" {
+		clientOpts = append(clientOpts, otlptracegrpc.WithCompressor("gzip"))
+	}
+	if opts.TLS != nil {
+		if opts.TLS.Insecure {
+			clientOpts = append(clientOpts, otlptracegrpc.WithInsecure())
+		} else {
+			tlsConfig, err := opts.TLS.build()
+			if err != nil {
+				return nil, err
+			}
+			clientOpts = append(clientOpts, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+		}
+	}
+	return clientOpts, nil
+}
+
+// build turns c into a *tls.Config, loading any configured CA/client certificate from disk.
+func (c *TLSConfig) build() (*tls.Config, error) {
+	tlsConfig := &tls.Config{ServerName: c.ServerName}
+
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("telemetry: reading CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("telemetry: no certificates found in %s", c.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("telemetry: loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
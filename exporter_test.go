@@ -0,0 +1,116 @@
+package telemetry
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTestCertKeyPair generates a self-signed certificate/key pair and writes them as PEM files
+// under dir, returning their paths.
+func writeTestCertKeyPair(t *testing.T, dir string) (certPath, keyPath string) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating test key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "telemetry-test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatalf("creating test certificate: %v", err)
+	}
+
+	keyBytes, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		t.Fatalf("marshaling test key: %v", err)
+	}
+
+	certPath = filepath.Join(dir, "cert.pem")
+	keyPath = filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("writing test certificate: %v", err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0o600); err != nil {
+		t.Fatalf("writing test key: %v", err)
+	}
+	return certPath, keyPath
+}
+
+func TestTLSConfigBuildLoadsCAAndClientCert(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := writeTestCertKeyPair(t, dir)
+
+	cfg := &TLSConfig{CAFile: certPath, CertFile: certPath, KeyFile: keyPath, ServerName: "example.com"}
+	tlsConfig, err := cfg.build()
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "example.com", tlsConfig.ServerName)
+	assert.NotNil(t, tlsConfig.RootCAs)
+	assert.Len(t, tlsConfig.Certificates, 1)
+}
+
+func TestTLSConfigBuildRejectsMissingCAFile(t *testing.T) {
+	cfg := &TLSConfig{CAFile: "/does/not/exist.pem"}
+	_, err := cfg.build()
+	assert.Error(t, err)
+}
+
+func TestTLSConfigBuildRejectsInvalidCAPEM(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bad.pem")
+	if err := os.WriteFile(path, []byte("not a cert"), 0o600); err != nil {
+		t.Fatalf("writing bad PEM: %v", err)
+	}
+
+	cfg := &TLSConfig{CAFile: path}
+	_, err := cfg.build()
+	assert.Error(t, err)
+}
+
+func TestHTTPClientOptionsAndGRPCClientOptions(t *testing.T) {
+	opts := Options{
+		Endpoint:    "collector:4318",
+		Headers:     map[string]string{"Authorization": "Bearer x"},
+		Timeout:     5 * time.Second,
+		Compression: "gzip",
+		TLS:         &TLSConfig{Insecure: true},
+	}
+
+	httpOpts, err := httpClientOptions(opts)
+	if assert.NoError(t, err) {
+		assert.Len(t, httpOpts, 5, "endpoint, headers, timeout, compression, and insecure TLS should each add one option")
+	}
+
+	grpcOpts, err := grpcClientOptions(opts)
+	if assert.NoError(t, err) {
+		assert.Len(t, grpcOpts, 5)
+	}
+}
+
+func TestHTTPClientOptionsPropagatesTLSBuildError(t *testing.T) {
+	opts := Options{TLS: &TLSConfig{CAFile: "/does/not/exist.pem"}}
+
+	_, err := httpClientOptions(opts)
+	assert.Error(t, err)
+
+	_, err = grpcClientOptions(opts)
+	assert.Error(t, err)
+}
@@ -0,0 +1,92 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// FilterDecision is the outcome of evaluating a Filter against a request. Its zero value, Defer,
+// leaves the decision to later filters or to the tracer's own sampler.
+type FilterDecision int
+
+const (
+	// Defer leaves the sampling decision to later filters in the chain, or to the tracer's
+	// sampler if no filter decides otherwise.
+	Defer FilterDecision = iota
+	// Sample is equivalent to Defer: it lets the tracer's sampler decide normally. It exists as
+	// a distinct value so a Filter can affirmatively state "I matched, but I'm not overriding
+	// sampling" as opposed to "I have no opinion".
+	Sample
+	// ForceSample forces the request to be sampled, unless any filter in the chain, earlier or
+	// later, returns Drop.
+	ForceSample
+	// Drop forces the request to never be sampled, regardless of any other filter's decision.
+	Drop
+)
+
+// Filter evaluates a request, and the route pattern it matched (empty if unknown), and returns a
+// FilterDecision. A Filter may also attach request-scoped span attributes by returning a context
+// built with WithFilterAttributes; NewHandlerWithConfig reads those back out and adds them to the
+// span it creates for the request.
+//
+// When multiple filters are configured on a handler, NewHandlerWithConfig evaluates all of them
+// and takes the highest-precedence decision in the order Drop > ForceSample > Sample/Defer, so
+// that any filter can veto another's ForceSample by returning Drop. None of the built-in filters
+// in filters.go attach attributes this way.
+//
+// Forcing a span to be sampled based on its eventual response status (e.g. always keeping 5xx
+// responses) can't be expressed as a Filter, since the status isn't known until after the handler
+// has run. That's implemented as a TailSamplingProcessor keep function instead; see
+// ErrorStatusKeep.
+type Filter interface {
+	Filter(ctx context.Context, r *http.Request, route string) (FilterDecision, context.Context)
+}
+
+type filterAttributesKey struct{}
+
+// WithFilterAttributes returns a context carrying additional span attributes, for a Filter that
+// wants to attach request-scoped data (e.g. a tenant ID extracted from a header) to the span
+// NewHandlerWithConfig creates. Repeated calls accumulate rather than replace earlier attributes.
+func WithFilterAttributes(ctx context.Context, attrs ...attribute.KeyValue) context.Context {
+	return context.WithValue(ctx, filterAttributesKey{}, append(filterAttributesFromContext(ctx), attrs...))
+}
+
+// filterAttributesFromContext returns the attributes attached via WithFilterAttributes, if any.
+func filterAttributesFromContext(ctx context.Context) []attribute.KeyValue {
+	attrs, _ := ctx.Value(filterAttributesKey{}).([]attribute.KeyValue)
+	return attrs
+}
+
+// FilterFunc adapts a plain function to a Filter.
+type FilterFunc func(ctx context.Context, r *http.Request, route string) (FilterDecision, context.Context)
+
+func (f FilterFunc) Filter(ctx context.Context, r *http.Request, route string) (FilterDecision, context.Context) {
+	return f(ctx, r, route)
+}
+
+// AdaptForceSampleFilter adapts a legacy ForceSampleFilter, which only sees the request and
+// returns a bool, into a Filter: true maps to ForceSample and false to Defer.
+func AdaptForceSampleFilter(f ForceSampleFilter) Filter {
+	return FilterFunc(func(ctx context.Context, r *http.Request, route string) (FilterDecision, context.Context) {
+		if f.ForceSample(r) {
+			return ForceSample, ctx
+		}
+		return Defer, ctx
+	})
+}
+
+// resolveFilters runs filters in order against r and route, returning the highest-precedence
+// decision and the context accumulated across all of them.
+func resolveFilters(ctx context.Context, r *http.Request, route string, filters []Filter) (FilterDecision, context.Context) {
+	decision := Defer
+	for _, filter := range filters {
+		var fd FilterDecision
+		fd, ctx = filter.Filter(ctx, r, route)
+		if fd > decision {
+			decision = fd
+		}
+	}
+	return decision, ctx
+}
@@ -0,0 +1,59 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func constFilter(decision FilterDecision) Filter {
+	return FilterFunc(func(ctx context.Context, r *http.Request, route string) (FilterDecision, context.Context) {
+		return decision, ctx
+	})
+}
+
+func TestResolveFiltersPrecedence(t *testing.T) {
+	r := httptest.NewRequest("GET", "/x", nil)
+
+	decision, _ := resolveFilters(context.Background(), r, "", nil)
+	assert.Equal(t, Defer, decision, "no filters should defer")
+
+	decision, _ = resolveFilters(context.Background(), r, "", []Filter{constFilter(Sample), constFilter(Defer)})
+	assert.Equal(t, Sample, decision)
+
+	decision, _ = resolveFilters(context.Background(), r, "", []Filter{constFilter(ForceSample), constFilter(Sample)})
+	assert.Equal(t, ForceSample, decision, "ForceSample should win over Sample")
+
+	decision, _ = resolveFilters(context.Background(), r, "", []Filter{constFilter(ForceSample), constFilter(Drop)})
+	assert.Equal(t, Drop, decision, "Drop should veto ForceSample regardless of order")
+
+	decision, _ = resolveFilters(context.Background(), r, "", []Filter{constFilter(Drop), constFilter(ForceSample)})
+	assert.Equal(t, Drop, decision, "Drop should win regardless of order")
+}
+
+func TestAdaptForceSampleFilter(t *testing.T) {
+	r := httptest.NewRequest("GET", "/x", nil)
+
+	f := AdaptForceSampleFilter(requestFilterFunc(func(r *http.Request) bool { return true }))
+	decision, _ := f.Filter(context.Background(), r, "")
+	assert.Equal(t, ForceSample, decision)
+
+	f = AdaptForceSampleFilter(requestFilterFunc(func(r *http.Request) bool { return false }))
+	decision, _ = f.Filter(context.Background(), r, "")
+	assert.Equal(t, Defer, decision)
+}
+
+func TestWithFilterAttributesAccumulates(t *testing.T) {
+	ctx := WithFilterAttributes(context.Background(), attribute.String("a", "1"))
+	ctx = WithFilterAttributes(ctx, attribute.String("b", "2"))
+
+	assert.Equal(t, []attribute.KeyValue{attribute.String("a", "1"), attribute.String("b", "2")}, filterAttributesFromContext(ctx))
+}
+
+func TestFilterAttributesFromContextEmptyByDefault(t *testing.T) {
+	assert.Empty(t, filterAttributesFromContext(context.Background()))
+}
@@ -0,0 +1,91 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// PathFilter returns a Filter that returns decision for requests whose URL path matches pattern
+// (path.Match glob syntax), and Defer otherwise.
+func PathFilter(pattern string, decision FilterDecision) (Filter, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("telemetry: invalid path pattern %q: %w", pattern, err)
+	}
+	return FilterFunc(func(ctx context.Context, r *http.Request, route string) (FilterDecision, context.Context) {
+		if ok, _ := path.Match(pattern, r.URL.Path); ok {
+			return decision, ctx
+		}
+		return Defer, ctx
+	}), nil
+}
+
+// PathRegexFilter returns a Filter that returns decision for requests whose URL path matches re,
+// and Defer otherwise.
+func PathRegexFilter(re *regexp.Regexp, decision FilterDecision) Filter {
+	return FilterFunc(func(ctx context.Context, r *http.Request, route string) (FilterDecision, context.Context) {
+		if re.MatchString(r.URL.Path) {
+			return decision, ctx
+		}
+		return Defer, ctx
+	})
+}
+
+// MethodFilter returns a Filter that returns decision for requests whose method is one of
+// methods, and Defer otherwise.
+func MethodFilter(decision FilterDecision, methods ...string) Filter {
+	allow := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		allow[strings.ToUpper(m)] = true
+	}
+	return FilterFunc(func(ctx context.Context, r *http.Request, route string) (FilterDecision, context.Context) {
+		if allow[r.Method] {
+			return decision, ctx
+		}
+		return Defer, ctx
+	})
+}
+
+// HeaderRegexFilter returns a Filter that returns decision for requests where the named header's
+// value matches re, and Defer otherwise.
+func HeaderRegexFilter(header string, re *regexp.Regexp, decision FilterDecision) Filter {
+	return FilterFunc(func(ctx context.Context, r *http.Request, route string) (FilterDecision, context.Context) {
+		if re.MatchString(r.Header.Get(header)) {
+			return decision, ctx
+		}
+		return Defer, ctx
+	})
+}
+
+// RemoteIPCIDRFilter returns a Filter that returns decision for requests whose remote IP falls
+// within any of cidrs, and Defer otherwise (including when the remote IP can't be parsed).
+func RemoteIPCIDRFilter(decision FilterDecision, cidrs ...string) (Filter, error) {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			return nil, fmt.Errorf("telemetry: invalid CIDR %q: %w", c, err)
+		}
+		nets = append(nets, n)
+	}
+	return FilterFunc(func(ctx context.Context, r *http.Request, route string) (FilterDecision, context.Context) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		ip := net.ParseIP(host)
+		if ip == nil {
+			return Defer, ctx
+		}
+		for _, n := range nets {
+			if n.Contains(ip) {
+				return decision, ctx
+			}
+		}
+		return Defer, ctx
+	}), nil
+}
@@ -0,0 +1,86 @@
+package telemetry
+
+import (
+	"context"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathFilter(t *testing.T) {
+	f, err := PathFilter("/users/*", ForceSample)
+	assert.NoError(t, err)
+
+	r := httptest.NewRequest("GET", "/users/42", nil)
+	decision, _ := f.Filter(context.Background(), r, "")
+	assert.Equal(t, ForceSample, decision)
+
+	r = httptest.NewRequest("GET", "/other", nil)
+	decision, _ = f.Filter(context.Background(), r, "")
+	assert.Equal(t, Defer, decision)
+
+	_, err = PathFilter("[", ForceSample)
+	assert.Error(t, err, "an invalid glob pattern should be rejected")
+}
+
+func TestPathRegexFilter(t *testing.T) {
+	f := PathRegexFilter(regexp.MustCompile(`^/admin/`), Drop)
+
+	r := httptest.NewRequest("GET", "/admin/settings", nil)
+	decision, _ := f.Filter(context.Background(), r, "")
+	assert.Equal(t, Drop, decision)
+
+	r = httptest.NewRequest("GET", "/users", nil)
+	decision, _ = f.Filter(context.Background(), r, "")
+	assert.Equal(t, Defer, decision)
+}
+
+func TestMethodFilter(t *testing.T) {
+	f := MethodFilter(ForceSample, "post", "put")
+
+	r := httptest.NewRequest("POST", "/x", nil)
+	decision, _ := f.Filter(context.Background(), r, "")
+	assert.Equal(t, ForceSample, decision)
+
+	r = httptest.NewRequest("GET", "/x", nil)
+	decision, _ = f.Filter(context.Background(), r, "")
+	assert.Equal(t, Defer, decision)
+}
+
+func TestHeaderRegexFilter(t *testing.T) {
+	f := HeaderRegexFilter("X-Debug", regexp.MustCompile(`^1$`), ForceSample)
+
+	r := httptest.NewRequest("GET", "/x", nil)
+	r.Header.Set("X-Debug", "1")
+	decision, _ := f.Filter(context.Background(), r, "")
+	assert.Equal(t, ForceSample, decision)
+
+	r = httptest.NewRequest("GET", "/x", nil)
+	decision, _ = f.Filter(context.Background(), r, "")
+	assert.Equal(t, Defer, decision)
+}
+
+func TestRemoteIPCIDRFilter(t *testing.T) {
+	f, err := RemoteIPCIDRFilter(Drop, "10.0.0.0/8")
+	assert.NoError(t, err)
+
+	r := httptest.NewRequest("GET", "/x", nil)
+	r.RemoteAddr = "10.1.2.3:1234"
+	decision, _ := f.Filter(context.Background(), r, "")
+	assert.Equal(t, Drop, decision)
+
+	r = httptest.NewRequest("GET", "/x", nil)
+	r.RemoteAddr = "192.168.1.1:1234"
+	decision, _ = f.Filter(context.Background(), r, "")
+	assert.Equal(t, Defer, decision)
+
+	r = httptest.NewRequest("GET", "/x", nil)
+	r.RemoteAddr = "not-an-ip"
+	decision, _ = f.Filter(context.Background(), r, "")
+	assert.Equal(t, Defer, decision, "an unparseable remote addr should defer, not error")
+
+	_, err = RemoteIPCIDRFilter(Drop, "not-a-cidr")
+	assert.Error(t, err)
+}
@@ -0,0 +1,42 @@
+package telemetry
+
+import (
+	"context"
+	"strings"
+
+	"github.com/getlantern/golog"
+	otellog "go.opentelemetry.io/otel/log"
+	logglobal "go.opentelemetry.io/otel/log/global"
+)
+
+// gologWriter adapts one of golog's two output streams (error or debug) into OTEL log records
+// via logger, tagging every record with severity.
+//
+// golog.SetOutputs hands us one io.Writer per severity bucket but no per-call context, so
+// records emitted this way carry no trace_id/span_id. Code paths that need log-to-trace
+// correlation should log through an otel/log Logger obtained from
+// go.opentelemetry.io/otel/log/global directly, passing the request's context, rather than via
+// golog.
+type gologWriter struct {
+	severity otellog.Severity
+	logger   otellog.Logger
+}
+
+func (w gologWriter) Write(p []byte) (int, error) {
+	var record otellog.Record
+	record.SetBody(otellog.StringValue(strings.TrimRight(string(p), "\n")))
+	record.SetSeverity(w.severity)
+	w.logger.Emit(context.Background(), record)
+	return len(p), nil
+}
+
+// BridgeGolog routes every golog record through the OTEL logs pipeline enabled by
+// EnableOTELLogs/EnableOTEL, mapping golog's error/fatal output to otellog.SeverityError and its
+// debug/trace output to otellog.SeverityDebug. Call it after enabling OTEL logs.
+func BridgeGolog() {
+	logger := logglobal.GetLoggerProvider().Logger(instrumentationName)
+	golog.SetOutputs(
+		gologWriter{severity: otellog.SeverityError, logger: logger},
+		gologWriter{severity: otellog.SeverityDebug, logger: logger},
+	)
+}
@@ -0,0 +1,180 @@
+package telemetry
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/getlantern/telemetry"
+
+type ForceSampleFilter interface {
+	ForceSample(r *http.Request) bool
+}
+
+type requestFilterFunc func(r *http.Request) bool
+
+func (rf requestFilterFunc) ForceSample(r *http.Request) bool {
+	return rf(r)
+}
+
+// Filter implements Filter directly (in addition to ForceSampleFilter) so that existing
+// requestFilterFunc values keep working as-is wherever a Filter is expected.
+func (rf requestFilterFunc) Filter(ctx context.Context, r *http.Request, route string) (FilterDecision, context.Context) {
+	if rf(r) {
+		return ForceSample, ctx
+	}
+	return Defer, ctx
+}
+
+// AlwaysSampleHTTPHeader returns a ForceSampleFilter that forces sampling for requests that have
+// the specified header set to the specified value.
+func AlwaysSampleHTTPHeader(header string, value string) ForceSampleFilter {
+	return requestFilterFunc(func(r *http.Request) bool {
+		return r.Header.Get(header) == value
+	})
+}
+
+// AlwaysSampleHeaderHandler wraps the passed handler and always samples requests that
+// have the specified header set to the specified value.
+func AlwaysSampleHeaderHandler(header string, value string, handler http.Handler) http.Handler {
+	return NewHandler(handler, AlwaysSampleHTTPHeader(header, value))
+}
+
+// SpanNameFormatter names the server span for an inbound request, given the route pattern the
+// handler was registered with (empty if unknown).
+type SpanNameFormatter func(route string, r *http.Request) string
+
+func defaultSpanNameFormatter(route string, r *http.Request) string {
+	if route == "" {
+		return r.Method
+	}
+	return r.Method + " " + route
+}
+
+// HandlerConfig configures the span/propagation instrumentation NewHandler wraps around a
+// handler. The zero value uses the global TracerProvider and TextMapPropagator and names spans
+// "METHOD" (or "METHOD route" when Route is set).
+type HandlerConfig struct {
+	// Route is the route pattern this handler serves, e.g. "/users/{id}". Recorded as the
+	// http.route span attribute and passed to SpanNameFormatter. Leave empty if unknown.
+	Route string
+	// SpanNameFormatter names the span for each request. Defaults to defaultSpanNameFormatter.
+	SpanNameFormatter SpanNameFormatter
+	// Tracer starts the server span for each request. Defaults to the global TracerProvider's
+	// "github.com/getlantern/telemetry" tracer.
+	Tracer trace.Tracer
+	// Propagator extracts incoming trace context/baggage from request headers. Defaults to the
+	// global TextMapPropagator.
+	Propagator propagation.TextMapPropagator
+}
+
+func (cfg HandlerConfig) tracer() trace.Tracer {
+	if cfg.Tracer != nil {
+		return cfg.Tracer
+	}
+	return otel.Tracer(instrumentationName)
+}
+
+func (cfg HandlerConfig) propagator() propagation.TextMapPropagator {
+	if cfg.Propagator != nil {
+		return cfg.Propagator
+	}
+	return otel.GetTextMapPropagator()
+}
+
+func (cfg HandlerConfig) spanName(r *http.Request) string {
+	formatter := cfg.SpanNameFormatter
+	if formatter == nil {
+		formatter = defaultSpanNameFormatter
+	}
+	return formatter(cfg.Route, r)
+}
+
+// NewHandler wraps handler, forcing the tracer to sample any request for which one of filters
+// returns true. This is NewHandlerWithConfig's original, simpler entry point, kept so existing
+// callers don't need to change their ForceSampleFilter values or add a HandlerConfig; it's
+// equivalent to NewHandlerWithConfig(handler, HandlerConfig{}, <filters adapted via
+// AdaptForceSampleFilter>...).
+func NewHandler(handler http.Handler, filters ...ForceSampleFilter) http.Handler {
+	adapted := make([]Filter, len(filters))
+	for i, f := range filters {
+		adapted[i] = AdaptForceSampleFilter(f)
+	}
+	return NewHandlerWithConfig(handler, HandlerConfig{}, adapted...)
+}
+
+// NewHandlerWithConfig wraps handler with OTEL HTTP server instrumentation: it extracts the
+// incoming W3C traceparent/baggage via cfg.Propagator, starts a server span named by
+// cfg.SpanNameFormatter, records HTTP semantic-convention attributes and the response status, and
+// evaluates filters to force-sample or drop requests matching ad hoc rules (e.g.
+// AlwaysSampleHTTPHeader via AdaptForceSampleFilter, PathFilter). When multiple filters are given,
+// the highest-precedence decision wins: Drop beats ForceSample beats Sample/Defer. See Filter for
+// details.
+func NewHandlerWithConfig(handler http.Handler, cfg HandlerConfig, filters ...Filter) http.Handler {
+	tracer := cfg.tracer()
+	propagator := cfg.propagator()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		decision, ctx := resolveFilters(ctx, r, cfg.Route, filters)
+		switch decision {
+		case Drop:
+			ctx = ForceDrop(ctx)
+		case ForceSample:
+			ctx = AlwaysSample(ctx)
+		}
+
+		attrs := append(serverRequestAttributes(cfg.Route, r), filterAttributesFromContext(ctx)...)
+		ctx, span := tracer.Start(ctx, cfg.spanName(r),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(attrs...),
+		)
+		defer span.End()
+
+		rw := &statusCapturingResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		handler.ServeHTTP(rw, r.WithContext(ctx))
+
+		span.SetAttributes(attribute.Int("http.status_code", rw.statusCode))
+		if rw.statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(rw.statusCode))
+		}
+	})
+}
+
+func serverRequestAttributes(route string, r *http.Request) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.String("http.method", r.Method),
+		attribute.String("http.target", r.URL.Path),
+	}
+	if route != "" {
+		attrs = append(attrs, attribute.String("http.route", route))
+	}
+	if host, port, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		attrs = append(attrs, attribute.String("net.peer.ip", host))
+		if p, err := strconv.Atoi(port); err == nil {
+			attrs = append(attrs, attribute.Int("net.peer.port", p))
+		}
+	}
+	return attrs
+}
+
+// statusCapturingResponseWriter records the status code written so it can be added to the span
+// after the inner handler returns.
+type statusCapturingResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (w *statusCapturingResponseWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
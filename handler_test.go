@@ -0,0 +1,80 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestNewHandlerWithConfigRecordsRequestAttributesAndStatus(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	base := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	h := NewHandlerWithConfig(base, HandlerConfig{Route: "/users/{id}", Tracer: tp.Tracer("test")})
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/users/42", nil)
+	h.ServeHTTP(rr, req)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	span := spans[0]
+
+	assert.Equal(t, "GET /users/{id}", span.Name)
+	assert.Equal(t, codes.Error, span.Status.Code)
+	assert.Contains(t, span.Attributes, attribute.String("http.route", "/users/{id}"))
+	assert.Contains(t, span.Attributes, attribute.Int("http.status_code", http.StatusInternalServerError))
+}
+
+func TestNewHandlerWithConfigMergesFilterAttributes(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	base := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	tenantFilter := FilterFunc(func(ctx context.Context, r *http.Request, route string) (FilterDecision, context.Context) {
+		return Defer, WithFilterAttributes(ctx, attribute.String("tenant.id", "acme"))
+	})
+	h := NewHandlerWithConfig(base, HandlerConfig{Tracer: tp.Tracer("test")}, tenantFilter)
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/x", nil)
+	h.ServeHTTP(rr, req)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes, attribute.String("tenant.id", "acme"))
+}
+
+func TestNewHandlerDelegatesToForceSampleFilters(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(ForceableSampler(sdktrace.NeverSample())), sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	defer otel.SetTracerProvider(previous)
+
+	base := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	h := NewHandler(base, requestFilterFunc(func(r *http.Request) bool { return true }))
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/x", nil)
+	h.ServeHTTP(rr, req)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 1)
+	assert.True(t, spans[0].SpanContext.IsSampled(), "NewHandler's ForceSampleFilter should still force sampling")
+}
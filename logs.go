@@ -0,0 +1,33 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	logglobal "go.opentelemetry.io/otel/log/global"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// EnableOTELLogs enables the OTEL logs pipeline, sharing opts.ResourceAttributes with
+// EnableOTELTracingWithOptions/EnableOTELMetrics. Call BridgeGolog afterwards to route this
+// package's (and the wider Lantern codebase's) golog output through it.
+func EnableOTELLogs(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	log.Debug("Enabling OTEL logs")
+
+	exp, err := newLogExporter(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: failed to initialize exporter: %w", err)
+	}
+
+	res, err := newResource(opts.ResourceAttributes)
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: failed to initialize resource: %w", err)
+	}
+
+	lp := sdklog.NewLoggerProvider(
+		sdklog.WithResource(res),
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exp)),
+	)
+	logglobal.SetLoggerProvider(lp)
+	return func(ctx context.Context) error { return lp.Shutdown(ctx) }, nil
+}
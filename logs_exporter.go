@@ -0,0 +1,87 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"google.golang.org/grpc/credentials"
+)
+
+// newLogExporter builds an OTLP log exporter for the transport selected by opts.
+func newLogExporter(ctx context.Context, opts Options) (sdklog.Exporter, error) {
+	switch opts.Transport {
+	case TransportGRPC:
+		clientOpts, err := grpcLogOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+		return otlploggrpc.New(ctx, clientOpts...)
+	case TransportHTTP, "":
+		clientOpts, err := httpLogOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+		return otlploghttp.New(ctx, clientOpts...)
+	default:
+		return nil, fmt.Errorf("telemetry: unsupported transport %q", opts.Transport)
+	}
+}
+
+func httpLogOptions(opts Options) ([]otlploghttp.Option, error) {
+	var clientOpts []otlploghttp.Option
+	if opts.Endpoint != "" {
+		clientOpts = append(clientOpts, otlploghttp.WithEndpoint(opts.Endpoint))
+	}
+	if len(opts.Headers) > 0 {
+		clientOpts = append(clientOpts, otlploghttp.WithHeaders(opts.Headers))
+	}
+	if opts.Timeout > 0 {
+		clientOpts = append(clientOpts, otlploghttp.WithTimeout(opts.Timeout))
+	}
+	if opts.Compression == "gzip" {
+		clientOpts = append(clientOpts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+	}
+	if opts.TLS != nil {
+		if opts.TLS.Insecure {
+			clientOpts = append(clientOpts, otlploghttp.WithInsecure())
+		} else {
+			tlsConfig, err := opts.TLS.build()
+			if err != nil {
+				return nil, err
+			}
+			clientOpts = append(clientOpts, otlploghttp.WithTLSClientConfig(tlsConfig))
+		}
+	}
+	return clientOpts, nil
+}
+
+func grpcLogOptions(opts Options) ([]otlploggrpc.Option, error) {
+	var clientOpts []otlploggrpc.Option
+	if opts.Endpoint != "" {
+		clientOpts = append(clientOpts, otlploggrpc.WithEndpoint(opts.Endpoint))
+	}
+	if len(opts.Headers) > 0 {
+		clientOpts = append(clientOpts, otlploggrpc.WithHeaders(opts.Headers))
+	}
+	if opts.Timeout > 0 {
+		clientOpts = append(clientOpts, otlploggrpc.WithTimeout(opts.Timeout))
+	}
+	if opts.Compression == "gzip" {
+		clientOpts = append(clientOpts, otlploggrpc.WithCompressor("gzip"))
+	}
+	if opts.TLS != nil {
+		if opts.TLS.Insecure {
+			clientOpts = append(clientOpts, otlploggrpc.WithInsecure())
+		} else {
+			tlsConfig, err := opts.TLS.build()
+			if err != nil {
+				return nil, err
+			}
+			clientOpts = append(clientOpts, otlploggrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+		}
+	}
+	return clientOpts, nil
+}
@@ -0,0 +1,44 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLogExporterRejectsUnsupportedTransport(t *testing.T) {
+	_, err := newLogExporter(context.Background(), Options{Transport: "carrier-pigeon"})
+	assert.Error(t, err)
+}
+
+func TestHTTPLogOptionsAndGRPCLogOptions(t *testing.T) {
+	opts := Options{
+		Endpoint:    "collector:4318",
+		Headers:     map[string]string{"Authorization": "Bearer x"},
+		Timeout:     5 * time.Second,
+		Compression: "gzip",
+		TLS:         &TLSConfig{Insecure: true},
+	}
+
+	httpOpts, err := httpLogOptions(opts)
+	if assert.NoError(t, err) {
+		assert.Len(t, httpOpts, 5, "endpoint, headers, timeout, compression, and insecure TLS should each add one option")
+	}
+
+	grpcOpts, err := grpcLogOptions(opts)
+	if assert.NoError(t, err) {
+		assert.Len(t, grpcOpts, 5)
+	}
+}
+
+func TestHTTPLogOptionsPropagatesTLSBuildError(t *testing.T) {
+	opts := Options{TLS: &TLSConfig{CAFile: "/does/not/exist.pem"}}
+
+	_, err := httpLogOptions(opts)
+	assert.Error(t, err)
+
+	_, err = grpcLogOptions(opts)
+	assert.Error(t, err)
+}
@@ -0,0 +1,57 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+// promHandler returns an http.Handler serving metrics from the default Prometheus registry,
+// which the prometheus exporter registers its collector with.
+func promHandler() http.Handler {
+	return promhttp.Handler()
+}
+
+// EnableOTELMetrics enables OTEL metrics collection, sharing opts.ResourceAttributes with
+// EnableOTELTracingWithOptions/EnableOTELLogs. It returns a shutdown func and, when
+// opts.MetricsExporter is MetricsExporterPrometheus, an http.Handler to serve at a scrape
+// endpoint; for MetricsExporterOTLP (the default) the returned handler is nil, since metrics are
+// pushed rather than scraped.
+func EnableOTELMetrics(ctx context.Context, opts Options) (func(context.Context) error, http.Handler, error) {
+	log.Debug("Enabling OTEL metrics")
+
+	res, err := newResource(opts.ResourceAttributes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("telemetry: failed to initialize resource: %w", err)
+	}
+
+	if opts.MetricsExporter == MetricsExporterPrometheus {
+		exp, err := prometheus.New()
+		if err != nil {
+			return nil, nil, fmt.Errorf("telemetry: failed to initialize prometheus exporter: %w", err)
+		}
+		mp := sdkmetric.NewMeterProvider(
+			sdkmetric.WithResource(res),
+			sdkmetric.WithReader(exp),
+		)
+		otel.SetMeterProvider(mp)
+		return func(ctx context.Context) error { return mp.Shutdown(ctx) }, promHandler(), nil
+	}
+
+	exp, err := newMetricExporter(ctx, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("telemetry: failed to initialize exporter: %w", err)
+	}
+
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithResource(res),
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exp)),
+	)
+	otel.SetMeterProvider(mp)
+	return func(ctx context.Context) error { return mp.Shutdown(ctx) }, nil, nil
+}
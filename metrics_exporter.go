@@ -0,0 +1,88 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"google.golang.org/grpc/credentials"
+)
+
+// newMetricExporter builds an OTLP metric exporter for the transport selected by opts. It is
+// only used when opts.MetricsExporter is MetricsExporterOTLP (the default).
+func newMetricExporter(ctx context.Context, opts Options) (sdkmetric.Exporter, error) {
+	switch opts.Transport {
+	case TransportGRPC:
+		clientOpts, err := grpcMetricOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+		return otlpmetricgrpc.New(ctx, clientOpts...)
+	case TransportHTTP, "":
+		clientOpts, err := httpMetricOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+		return otlpmetrichttp.New(ctx, clientOpts...)
+	default:
+		return nil, fmt.Errorf("telemetry: unsupported transport %q", opts.Transport)
+	}
+}
+
+func httpMetricOptions(opts Options) ([]otlpmetrichttp.Option, error) {
+	var clientOpts []otlpmetrichttp.Option
+	if opts.Endpoint != "" {
+		clientOpts = append(clientOpts, otlpmetrichttp.WithEndpoint(opts.Endpoint))
+	}
+	if len(opts.Headers) > 0 {
+		clientOpts = append(clientOpts, otlpmetrichttp.WithHeaders(opts.Headers))
+	}
+	if opts.Timeout > 0 {
+		clientOpts = append(clientOpts, otlpmetrichttp.WithTimeout(opts.Timeout))
+	}
+	if opts.Compression == "gzip" {
+		clientOpts = append(clientOpts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+	}
+	if opts.TLS != nil {
+		if opts.TLS.Insecure {
+			clientOpts = append(clientOpts, otlpmetrichttp.WithInsecure())
+		} else {
+			tlsConfig, err := opts.TLS.build()
+			if err != nil {
+				return nil, err
+			}
+			clientOpts = append(clientOpts, otlpmetrichttp.WithTLSClientConfig(tlsConfig))
+		}
+	}
+	return clientOpts, nil
+}
+
+func grpcMetricOptions(opts Options) ([]otlpmetricgrpc.Option, error) {
+	var clientOpts []otlpmetricgrpc.Option
+	if opts.Endpoint != "" {
+		clientOpts = append(clientOpts, otlpmetricgrpc.WithEndpoint(opts.Endpoint))
+	}
+	if len(opts.Headers) > 0 {
+		clientOpts = append(clientOpts, otlpmetricgrpc.WithHeaders(opts.Headers))
+	}
+	if opts.Timeout > 0 {
+		clientOpts = append(clientOpts, otlpmetricgrpc.WithTimeout(opts.Timeout))
+	}
+	if opts.Compression == "gzip" {
+		clientOpts = append(clientOpts, otlpmetricgrpc.WithCompressor("gzip"))
+	}
+	if opts.TLS != nil {
+		if opts.TLS.Insecure {
+			clientOpts = append(clientOpts, otlpmetricgrpc.WithInsecure())
+		} else {
+			tlsConfig, err := opts.TLS.build()
+			if err != nil {
+				return nil, err
+			}
+			clientOpts = append(clientOpts, otlpmetricgrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+		}
+	}
+	return clientOpts, nil
+}
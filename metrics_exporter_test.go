@@ -0,0 +1,44 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMetricExporterRejectsUnsupportedTransport(t *testing.T) {
+	_, err := newMetricExporter(context.Background(), Options{Transport: "carrier-pigeon"})
+	assert.Error(t, err)
+}
+
+func TestHTTPMetricOptionsAndGRPCMetricOptions(t *testing.T) {
+	opts := Options{
+		Endpoint:    "collector:4318",
+		Headers:     map[string]string{"Authorization": "Bearer x"},
+		Timeout:     5 * time.Second,
+		Compression: "gzip",
+		TLS:         &TLSConfig{Insecure: true},
+	}
+
+	httpOpts, err := httpMetricOptions(opts)
+	if assert.NoError(t, err) {
+		assert.Len(t, httpOpts, 5, "endpoint, headers, timeout, compression, and insecure TLS should each add one option")
+	}
+
+	grpcOpts, err := grpcMetricOptions(opts)
+	if assert.NoError(t, err) {
+		assert.Len(t, grpcOpts, 5)
+	}
+}
+
+func TestHTTPMetricOptionsPropagatesTLSBuildError(t *testing.T) {
+	opts := Options{TLS: &TLSConfig{CAFile: "/does/not/exist.pem"}}
+
+	_, err := httpMetricOptions(opts)
+	assert.Error(t, err)
+
+	_, err = grpcMetricOptions(opts)
+	assert.Error(t, err)
+}
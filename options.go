@@ -0,0 +1,123 @@
+package telemetry
+
+import (
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// TailSamplingKeep decides whether an already-recorded span should actually be exported. See
+// TailSamplingProcessor.
+type TailSamplingKeep func(s sdktrace.ReadOnlySpan) bool
+
+// Transport selects the wire protocol used to talk to the OTLP collector.
+type Transport string
+
+const (
+	TransportHTTP Transport = "http"
+	TransportGRPC Transport = "grpc"
+)
+
+// MetricsExporterKind selects the metrics exporter EnableOTELMetrics builds.
+type MetricsExporterKind string
+
+const (
+	// MetricsExporterOTLP pushes metrics to an OTLP collector over Options.Transport. This is
+	// the default.
+	MetricsExporterOTLP MetricsExporterKind = "otlp"
+	// MetricsExporterPrometheus serves metrics for scraping instead of pushing them; see the
+	// http.Handler EnableOTELMetrics and EnableOTEL return alongside the shutdown func.
+	MetricsExporterPrometheus MetricsExporterKind = "prometheus"
+)
+
+// TLSConfig configures transport security for the OTLP exporter. A nil *TLSConfig leaves the
+// exporter's own default (TLS using the system cert pool) in place.
+type TLSConfig struct {
+	// Insecure disables TLS entirely, connecting in plaintext.
+	Insecure bool
+	// CAFile, if set, is a PEM file of additional CAs to trust.
+	CAFile string
+	// CertFile and KeyFile, if set, are a PEM client certificate/key pair for mTLS.
+	CertFile string
+	KeyFile  string
+	// ServerName overrides the server name used for certificate verification.
+	ServerName string
+}
+
+// BatchOptions exposes the sdktrace.BatchSpanProcessor tuning knobs. A zero value for any field
+// leaves the SDK's own default for that field in place.
+type BatchOptions struct {
+	MaxQueueSize       int
+	MaxExportBatchSize int
+	BatchTimeout       time.Duration
+	ExportTimeout      time.Duration
+}
+
+func (b BatchOptions) asProcessorOptions() []sdktrace.BatchSpanProcessorOption {
+	var opts []sdktrace.BatchSpanProcessorOption
+	if b.MaxQueueSize > 0 {
+		opts = append(opts, sdktrace.WithMaxQueueSize(b.MaxQueueSize))
+	}
+	if b.MaxExportBatchSize > 0 {
+		opts = append(opts, sdktrace.WithMaxExportBatchSize(b.MaxExportBatchSize))
+	}
+	if b.BatchTimeout > 0 {
+		opts = append(opts, sdktrace.WithBatchTimeout(b.BatchTimeout))
+	}
+	if b.ExportTimeout > 0 {
+		opts = append(opts, sdktrace.WithExportTimeout(b.ExportTimeout))
+	}
+	return opts
+}
+
+// Options configures EnableOTELTracingWithOptions. The zero value selects an otlptracehttp
+// exporter with all SDK/exporter defaults, and a sampler derived from
+// OTEL_TRACES_SAMPLER/OTEL_TRACES_SAMPLER_ARG (the same as EnableOTELTracing).
+type Options struct {
+	// Transport selects the OTLP wire protocol. Defaults to TransportHTTP.
+	Transport Transport
+	// Endpoint is the OTLP collector endpoint. Defaults to the exporter's own default
+	// (localhost:4317 for grpc, localhost:4318 for http) when empty.
+	Endpoint string
+	// Headers are added to every export request, e.g. for collector authentication.
+	Headers map[string]string
+	// TLS configures transport security. Leave nil to use the exporter's own default.
+	TLS *TLSConfig
+	// Timeout bounds each export request. Defaults to the exporter's own default when zero.
+	Timeout time.Duration
+	// Compression selects payload compression. Set to "gzip" to enable; leave empty for none.
+	Compression string
+	// ResourceAttributes are merged over the sdk/resource defaults (service.name,
+	// telemetry.sdk.*, ...), taking precedence on key conflicts.
+	ResourceAttributes map[string]string
+	// MetricsExporter selects the exporter EnableOTELMetrics (and EnableOTEL) build for metrics.
+	// Defaults to MetricsExporterOTLP; ignored by EnableOTELTracingWithOptions/EnableOTELLogs.
+	MetricsExporter MetricsExporterKind
+	// Batch tunes the batch span processor. Zero fields use the SDK's own defaults.
+	Batch BatchOptions
+	// TailSamplingKeep, if non-nil, wraps the batch span processor in a TailSamplingProcessor
+	// that only exports spans it approves, letting decisions that can only be made at response
+	// time (minimum latency, error status, see MinLatencyKeep/ErrorStatusKeep) drop spans that
+	// were recorded but shouldn't be exported.
+	TailSamplingKeep TailSamplingKeep
+	// Sampler overrides the sampler. If nil, one is derived from
+	// OTEL_TRACES_SAMPLER/OTEL_TRACES_SAMPLER_ARG via samplerFromEnv, falling back to the SDK's
+	// own default (ParentBased(AlwaysSample)) if neither is set.
+	Sampler sdktrace.Sampler
+}
+
+// resolveSampler returns sampler if non-nil, otherwise falls back to samplerFromEnv, and finally
+// to the SDK's own default sampler if no OTEL_TRACES_SAMPLER is configured either.
+func resolveSampler(sampler sdktrace.Sampler) (sdktrace.Sampler, error) {
+	if sampler != nil {
+		return sampler, nil
+	}
+	envSampler, err := samplerFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	if envSampler != nil {
+		return envSampler, nil
+	}
+	return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+}
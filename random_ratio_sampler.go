@@ -0,0 +1,55 @@
+package telemetry
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// randomRatioSampler samples a fraction of traces by comparing the trailing 8 bytes of the trace
+// ID against a threshold precomputed at construction time. This avoids the per-decision floating
+// point multiplication that trace.TraceIDRatioBased performs, at the cost of only being
+// constructible up front.
+type randomRatioSampler struct {
+	fraction  float64
+	threshold uint64
+}
+
+// RandomRatioBased returns a Sampler that samples a given fraction of traces, deterministically
+// from the trace ID, as trace.TraceIDRatioBased does. Unlike TraceIDRatioBased, the sampling
+// threshold is precomputed once here so that ShouldSample is a single unsigned comparison. A
+// fraction >= 1 returns an AlwaysSample sampler and a fraction <= 0 returns a NeverSample
+// sampler, both decided at construction time rather than on every call.
+func RandomRatioBased(fraction float64) sdktrace.Sampler {
+	if fraction >= 1 {
+		return sdktrace.AlwaysSample()
+	}
+	if fraction <= 0 {
+		return sdktrace.NeverSample()
+	}
+	return &randomRatioSampler{
+		fraction:  fraction,
+		threshold: uint64(fraction * float64(math.MaxUint64)),
+	}
+}
+
+func (s *randomRatioSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	psc := trace.SpanContextFromContext(p.ParentContext)
+	if binary.BigEndian.Uint64(p.TraceID[8:16]) < s.threshold {
+		return sdktrace.SamplingResult{
+			Decision:   sdktrace.RecordAndSample,
+			Tracestate: psc.TraceState(),
+		}
+	}
+	return sdktrace.SamplingResult{
+		Decision:   sdktrace.Drop,
+		Tracestate: psc.TraceState(),
+	}
+}
+
+func (s *randomRatioSampler) Description() string {
+	return fmt.Sprintf("RandomRatioBased{%g}", s.fraction)
+}
@@ -0,0 +1,40 @@
+package telemetry
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestRandomRatioBasedObservedRate(t *testing.T) {
+	const (
+		fraction  = 0.25
+		n         = 1_000_000
+		tolerance = 0.01
+	)
+
+	sampler := RandomRatioBased(fraction)
+	r := rand.New(rand.NewSource(1))
+
+	sampled := 0
+	for i := 0; i < n; i++ {
+		var traceID trace.TraceID
+		r.Read(traceID[:])
+		result := sampler.ShouldSample(sdktrace.SamplingParameters{TraceID: traceID})
+		if result.Decision == sdktrace.RecordAndSample {
+			sampled++
+		}
+	}
+
+	assert.InDelta(t, fraction, float64(sampled)/float64(n), tolerance)
+}
+
+func TestRandomRatioBasedBounds(t *testing.T) {
+	assert.Equal(t, sdktrace.AlwaysSample(), RandomRatioBased(1.0))
+	assert.Equal(t, sdktrace.AlwaysSample(), RandomRatioBased(1.5))
+	assert.Equal(t, sdktrace.NeverSample(), RandomRatioBased(0.0))
+	assert.Equal(t, sdktrace.NeverSample(), RandomRatioBased(-1.0))
+}
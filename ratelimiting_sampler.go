@@ -0,0 +1,77 @@
+package telemetry
+
+import (
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// rateLimitingSampler is a sdktrace.Sampler that admits at most a fixed number of traces per
+// second regardless of trace ID, using a leaky bucket: the bucket starts full and refills
+// continuously at ratePerSec, and a trace is sampled only if the bucket holds at least one
+// whole unit at decision time.
+type rateLimitingSampler struct {
+	mu          sync.Mutex
+	ratePerSec  float64
+	maxBalance  float64
+	balance     float64
+	lastUpdated time.Time
+	now         func() time.Time
+}
+
+// NewRateLimitingSampler returns a Sampler that admits at most tracesPerSecond traces per
+// second. A tracesPerSecond <= 0 never samples.
+func NewRateLimitingSampler(tracesPerSecond float64) sdktrace.Sampler {
+	if tracesPerSecond <= 0 {
+		return sdktrace.NeverSample()
+	}
+	maxBalance := tracesPerSecond
+	if maxBalance < 1 {
+		maxBalance = 1
+	}
+	return &rateLimitingSampler{
+		ratePerSec:  tracesPerSecond,
+		maxBalance:  maxBalance,
+		balance:     maxBalance,
+		lastUpdated: time.Now(),
+		now:         time.Now,
+	}
+}
+
+func (s *rateLimitingSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	psc := trace.SpanContextFromContext(p.ParentContext)
+	if s.allow() {
+		return sdktrace.SamplingResult{
+			Decision:   sdktrace.RecordAndSample,
+			Tracestate: psc.TraceState(),
+		}
+	}
+	return sdktrace.SamplingResult{
+		Decision:   sdktrace.Drop,
+		Tracestate: psc.TraceState(),
+	}
+}
+
+func (s *rateLimitingSampler) allow() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.balance += now.Sub(s.lastUpdated).Seconds() * s.ratePerSec
+	if s.balance > s.maxBalance {
+		s.balance = s.maxBalance
+	}
+	s.lastUpdated = now
+
+	if s.balance < 1 {
+		return false
+	}
+	s.balance--
+	return true
+}
+
+func (s *rateLimitingSampler) Description() string {
+	return "RateLimitingSampler"
+}
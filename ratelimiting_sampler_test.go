@@ -0,0 +1,44 @@
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestRateLimitingSamplerAdmitsUpToBurstThenRefillsOverTime(t *testing.T) {
+	clock := time.Unix(0, 0)
+	s := &rateLimitingSampler{
+		ratePerSec:  2,
+		maxBalance:  2,
+		balance:     2,
+		lastUpdated: clock,
+		now:         func() time.Time { return clock },
+	}
+	params := sdktrace.SamplingParameters{}
+
+	assert.Equal(t, sdktrace.RecordAndSample, s.ShouldSample(params).Decision)
+	assert.Equal(t, sdktrace.RecordAndSample, s.ShouldSample(params).Decision)
+	assert.Equal(t, sdktrace.Drop, s.ShouldSample(params).Decision, "bucket should be exhausted after 2 admits")
+
+	clock = clock.Add(500 * time.Millisecond)
+	assert.Equal(t, sdktrace.RecordAndSample, s.ShouldSample(params).Decision, "half a second at 2/s should refill exactly one unit")
+	assert.Equal(t, sdktrace.Drop, s.ShouldSample(params).Decision)
+
+	clock = clock.Add(10 * time.Second)
+	assert.Equal(t, sdktrace.RecordAndSample, s.ShouldSample(params).Decision, "a long gap should only refill up to maxBalance, not accumulate unbounded credit")
+	assert.Equal(t, sdktrace.RecordAndSample, s.ShouldSample(params).Decision)
+	assert.Equal(t, sdktrace.Drop, s.ShouldSample(params).Decision)
+}
+
+func TestNewRateLimitingSamplerNonPositiveRateNeverSamples(t *testing.T) {
+	assert.Equal(t, sdktrace.NeverSample(), NewRateLimitingSampler(0))
+	assert.Equal(t, sdktrace.NeverSample(), NewRateLimitingSampler(-1))
+}
+
+func TestNewRateLimitingSamplerFractionalRateHasBurstOfOne(t *testing.T) {
+	s := NewRateLimitingSampler(0.5).(*rateLimitingSampler)
+	assert.Equal(t, 1.0, s.maxBalance, "a sub-1 rate should still admit a burst of 1")
+}
@@ -0,0 +1,111 @@
+package telemetry
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/samplers/jaegerremote"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	defaultRemotePollInterval = time.Minute
+	defaultRemoteInitialRatio = 0.001
+	defaultRemoteServiceName  = "telemetry"
+)
+
+// RemoteSamplerConfig configures a sampler whose sampling strategy is pulled from a remote
+// collector/agent at runtime over the Jaeger remote sampling protocol, via
+// go.opentelemetry.io/contrib/samplers/jaegerremote.
+type RemoteSamplerConfig struct {
+	// Endpoint is the base URL of the collector/agent serving remote sampling strategies, e.g.
+	// "http://jaeger-collector:5778/sampling".
+	Endpoint string
+	// ServiceName identifies this service when requesting a sampling strategy. Defaults to
+	// "telemetry" if empty.
+	ServiceName string
+	// PollInterval controls how often the remote strategy is refreshed. Defaults to 1 minute.
+	PollInterval time.Duration
+	// InitialSampler is used for sampling decisions until the first remote strategy is fetched.
+	// Defaults to a 0.001 TraceIDRatioBased sampler.
+	InitialSampler sdktrace.Sampler
+}
+
+// newRemoteSampler returns a sdktrace.Sampler backed by jaegerremote.New, which starts a
+// background goroutine that polls cfg.Endpoint for a new sampling strategy every PollInterval for
+// as long as the sampler is reachable. The returned sampler also implements io.Closer (via
+// closerOf) to stop that goroutine; EnableOTELTracingWithOptions calls it from the shutdown func
+// it returns, so every caller of newRemoteSampler must ensure the sampler it builds ends up
+// reachable from there (directly, or via closingSampler if something wraps it, e.g. in
+// trace.ParentBased) rather than discarded.
+func newRemoteSampler(cfg RemoteSamplerConfig) (sdktrace.Sampler, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("telemetry: remote sampler requires an endpoint")
+	}
+
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = defaultRemoteServiceName
+	}
+	pollInterval := cfg.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultRemotePollInterval
+	}
+	initial := cfg.InitialSampler
+	if initial == nil {
+		initial = sdktrace.TraceIDRatioBased(defaultRemoteInitialRatio)
+	}
+
+	return jaegerremote.New(
+		serviceName,
+		jaegerremote.WithSamplingServerURL(cfg.Endpoint),
+		jaegerremote.WithSamplingRefreshInterval(pollInterval),
+		jaegerremote.WithInitialSampler(initial),
+	), nil
+}
+
+// parseRemoteSamplerArg parses an OTEL_TRACES_SAMPLER_ARG value of the form
+// "endpoint=...,pollInterval=...,serviceName=...,initialSamplingRate=..." into a
+// RemoteSamplerConfig. endpoint is required; the rest fall back to their defaults.
+func parseRemoteSamplerArg(arg string) (RemoteSamplerConfig, error) {
+	var cfg RemoteSamplerConfig
+	for _, kv := range strings.Split(arg, ",") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" {
+			continue
+		}
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 {
+			return cfg, fmt.Errorf("telemetry: invalid remote sampler argument %q", kv)
+		}
+		key, value := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+		switch key {
+		case "endpoint":
+			cfg.Endpoint = value
+		case "serviceName":
+			cfg.ServiceName = value
+		case "pollInterval":
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return cfg, samplerArgParseError{err}
+			}
+			cfg.PollInterval = d
+		case "initialSamplingRate":
+			ratio, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return cfg, samplerArgParseError{err}
+			}
+			sampler, err := validateTraceIDRatio(ratio)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.InitialSampler = sampler
+		default:
+			return cfg, fmt.Errorf("telemetry: unknown remote sampler argument %q", key)
+		}
+	}
+	return cfg, nil
+}
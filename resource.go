@@ -0,0 +1,22 @@
+package telemetry
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// newResource builds the Resource reported alongside every span: the sdk/resource package's own
+// defaults (service.name, telemetry.sdk.*, ...) merged with attrs, with attrs taking precedence
+// on key conflicts.
+func newResource(attrs map[string]string) (*resource.Resource, error) {
+	if len(attrs) == 0 {
+		return resource.Default(), nil
+	}
+
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for k, v := range attrs {
+		kvs = append(kvs, attribute.String(k, v))
+	}
+
+	return resource.Merge(resource.Default(), resource.NewSchemaless(kvs...))
+}
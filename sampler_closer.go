@@ -0,0 +1,54 @@
+package telemetry
+
+import (
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// closingSampler pairs a sdktrace.Sampler with an io.Closer. It exists because wrapping a
+// sampler in sdktrace.ParentBased (or any other composite) produces a value of a different
+// concrete type, so a caller that needs to release resources held by the wrapped sampler (e.g.
+// newRemoteSampler's background polling goroutine) can no longer reach it via a type assertion on
+// the composite. Building the composite, then wrapping it in closingSampler alongside the
+// original closer, keeps that capability reachable.
+type closingSampler struct {
+	sdktrace.Sampler
+	closer interface{ Close() error }
+}
+
+func (s closingSampler) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// errorlessCloser matches the shape of jaegerremote.Sampler's own Close method, which (unlike
+// io.Closer) returns nothing.
+type errorlessCloser interface {
+	Close()
+}
+
+// errorlessCloserAdapter adapts an errorlessCloser to the interface{ Close() error } shape
+// closerOf normalizes to, always reporting a nil error.
+type errorlessCloserAdapter struct {
+	closer errorlessCloser
+}
+
+func (a errorlessCloserAdapter) Close() error {
+	a.closer.Close()
+	return nil
+}
+
+// closerOf returns sampler's closer, normalized to interface{ Close() error }, if it has one.
+// It recognizes both io.Closer and the error-less Close() shape jaegerremote.Sampler actually
+// uses, so callers can release resources (such as newRemoteSampler's background poller) when
+// tearing a sampler down regardless of which shape the concrete sampler exposes.
+func closerOf(sampler sdktrace.Sampler) (interface{ Close() error }, bool) {
+	if closer, ok := sampler.(interface{ Close() error }); ok {
+		return closer, true
+	}
+	if closer, ok := sampler.(errorlessCloser); ok {
+		return errorlessCloserAdapter{closer: closer}, true
+	}
+	return nil, false
+}
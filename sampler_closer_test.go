@@ -0,0 +1,26 @@
+package telemetry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestCloserOfRecognizesRealRemoteSampler(t *testing.T) {
+	sampler, err := newRemoteSampler(RemoteSamplerConfig{Endpoint: "http://127.0.0.1:0/sampling"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	closer, ok := closerOf(sampler)
+	if !assert.True(t, ok, "closerOf should recognize jaegerremote.Sampler's error-less Close() method") {
+		return
+	}
+	assert.NoError(t, closer.Close())
+}
+
+func TestCloserOfFalseForNonCloserSampler(t *testing.T) {
+	_, ok := closerOf(sdktrace.AlwaysSample())
+	assert.False(t, ok)
+}
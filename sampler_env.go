@@ -34,6 +34,24 @@ const (
 	samplerParentBasedAlwaysOn     = "parentbased_always_on"
 	samplerParsedBasedAlwaysOff    = "parentbased_always_off"
 	samplerParentBasedTraceIDRatio = "parentbased_traceidratio"
+
+	// samplerConst, samplerProbabilistic, samplerRateLimiting and samplerRemote mirror the
+	// Jaeger-style sampler taxonomy (https://www.jaegertracing.io/docs/latest/sampling/),
+	// alongside the names OTEL already defines above.
+	samplerConst                    = "const"
+	samplerProbabilistic            = "probabilistic"
+	samplerRateLimiting             = "ratelimiting"
+	samplerRemote                   = "remote"
+	samplerParentBasedConst         = "parentbased_const"
+	samplerParentBasedProbabilistic = "parentbased_probabilistic"
+	samplerParentBasedRateLimiting  = "parentbased_ratelimiting"
+	samplerParentBasedRemote        = "parentbased_remote"
+
+	// samplerRandomRatio is a drop-in replacement for samplerTraceIDRatio that precomputes its
+	// sampling threshold once at construction instead of on every ShouldSample call. See
+	// RandomRatioBased.
+	samplerRandomRatio            = "randomratio"
+	samplerParentBasedRandomRatio = "parentbased_randomratio"
 )
 
 type errUnsupportedSampler string
@@ -45,6 +63,7 @@ func (e errUnsupportedSampler) Error() string {
 var (
 	errNegativeTraceIDRatio       = errors.New("invalid trace ID ratio: less than 0.0")
 	errGreaterThanOneTraceIDRatio = errors.New("invalid trace ID ratio: greater than 1.0")
+	errNegativeRateLimit          = errors.New("invalid rate limit: less than 0.0")
 )
 
 type samplerArgParseError struct {
@@ -89,22 +108,121 @@ func samplerFromEnv() (trace.Sampler, error) {
 		}
 		ratio, err := parseTraceIDRatio(samplerArg)
 		return trace.ParentBased(ratio), err
+	case samplerConst:
+		return parseConstSampler(samplerArg), nil
+	case samplerParentBasedConst:
+		return trace.ParentBased(parseConstSampler(samplerArg)), nil
+	case samplerProbabilistic:
+		if !hasSamplerArg {
+			return trace.TraceIDRatioBased(1.0), nil
+		}
+		return parseTraceIDRatio(samplerArg)
+	case samplerParentBasedProbabilistic:
+		if !hasSamplerArg {
+			return trace.ParentBased(trace.TraceIDRatioBased(1.0)), nil
+		}
+		ratio, err := parseTraceIDRatio(samplerArg)
+		return trace.ParentBased(ratio), err
+	case samplerRateLimiting:
+		rate, err := parseRateLimit(samplerArg)
+		if err != nil {
+			return nil, err
+		}
+		return NewRateLimitingSampler(rate), nil
+	case samplerParentBasedRateLimiting:
+		rate, err := parseRateLimit(samplerArg)
+		if err != nil {
+			return nil, err
+		}
+		return trace.ParentBased(NewRateLimitingSampler(rate)), nil
+	case samplerRemote:
+		remoteCfg, err := parseRemoteSamplerArg(samplerArg)
+		if err != nil {
+			return nil, err
+		}
+		return newRemoteSampler(remoteCfg)
+	case samplerParentBasedRemote:
+		remoteCfg, err := parseRemoteSamplerArg(samplerArg)
+		if err != nil {
+			return nil, err
+		}
+		remoteSampler, err := newRemoteSampler(remoteCfg)
+		if err != nil {
+			return nil, err
+		}
+		parentBased := trace.ParentBased(remoteSampler)
+		if closer, ok := closerOf(remoteSampler); ok {
+			return closingSampler{Sampler: parentBased, closer: closer}, nil
+		}
+		return parentBased, nil
+	case samplerRandomRatio:
+		if !hasSamplerArg {
+			return RandomRatioBased(1.0), nil
+		}
+		return parseRandomRatio(samplerArg)
+	case samplerParentBasedRandomRatio:
+		if !hasSamplerArg {
+			return trace.ParentBased(RandomRatioBased(1.0)), nil
+		}
+		randomRatio, err := parseRandomRatio(samplerArg)
+		return trace.ParentBased(randomRatio), err
 	default:
 		return nil, errUnsupportedSampler(sampler)
 	}
 }
 
+// parseConstSampler interprets a Jaeger-style "const" sampler argument: "1" or "true"
+// (case-insensitive) always samples, everything else (including a missing argument) never does.
+func parseConstSampler(arg string) trace.Sampler {
+	switch strings.ToLower(arg) {
+	case "1", "true":
+		return trace.AlwaysSample()
+	default:
+		return trace.NeverSample()
+	}
+}
+
+// parseRateLimit parses a "ratelimiting" sampler argument as the maximum number of traces per
+// second to admit.
+func parseRateLimit(arg string) (float64, error) {
+	rate, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return 0, samplerArgParseError{err}
+	}
+	if rate < 0.0 {
+		return 0, errNegativeRateLimit
+	}
+	return rate, nil
+}
+
+// parseRandomRatio parses a "randomratio" sampler argument as a fraction. Unlike
+// parseTraceIDRatio, out-of-range fractions aren't an error: RandomRatioBased clamps them to
+// AlwaysSample/NeverSample.
+func parseRandomRatio(arg string) (trace.Sampler, error) {
+	v, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return RandomRatioBased(1.0), samplerArgParseError{err}
+	}
+	return RandomRatioBased(v), nil
+}
+
 func parseTraceIDRatio(arg string) (trace.Sampler, error) {
 	v, err := strconv.ParseFloat(arg, 64)
 	if err != nil {
 		return trace.TraceIDRatioBased(1.0), samplerArgParseError{err}
 	}
-	if v < 0.0 {
+	return validateTraceIDRatio(v)
+}
+
+// validateTraceIDRatio builds a TraceIDRatioBased sampler from ratio, rejecting values outside
+// of [0.0, 1.0].
+func validateTraceIDRatio(ratio float64) (trace.Sampler, error) {
+	if ratio < 0.0 {
 		return trace.TraceIDRatioBased(1.0), errNegativeTraceIDRatio
 	}
-	if v > 1.0 {
+	if ratio > 1.0 {
 		return trace.TraceIDRatioBased(1.0), errGreaterThanOneTraceIDRatio
 	}
 
-	return trace.TraceIDRatioBased(v), nil
+	return trace.TraceIDRatioBased(ratio), nil
 }
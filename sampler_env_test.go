@@ -0,0 +1,60 @@
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	rate, err := parseRateLimit("5.5")
+	assert.NoError(t, err)
+	assert.Equal(t, 5.5, rate)
+
+	_, err = parseRateLimit("not-a-number")
+	assert.Error(t, err)
+
+	_, err = parseRateLimit("-1")
+	assert.ErrorIs(t, err, errNegativeRateLimit)
+}
+
+func TestParseRemoteSamplerArg(t *testing.T) {
+	cfg, err := parseRemoteSamplerArg("endpoint=http://collector:5778/sampling,serviceName=svc,pollInterval=30s,initialSamplingRate=0.5")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://collector:5778/sampling", cfg.Endpoint)
+	assert.Equal(t, "svc", cfg.ServiceName)
+	assert.Equal(t, 30*time.Second, cfg.PollInterval)
+	assert.NotNil(t, cfg.InitialSampler)
+}
+
+func TestParseRemoteSamplerArgDefaults(t *testing.T) {
+	cfg, err := parseRemoteSamplerArg("endpoint=http://collector:5778/sampling")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://collector:5778/sampling", cfg.Endpoint)
+	assert.Empty(t, cfg.ServiceName)
+	assert.Zero(t, cfg.PollInterval)
+	assert.Nil(t, cfg.InitialSampler)
+}
+
+func TestParseRemoteSamplerArgErrors(t *testing.T) {
+	_, err := parseRemoteSamplerArg("endpoint")
+	assert.Error(t, err, "a key with no '=' should fail to parse")
+
+	_, err = parseRemoteSamplerArg("endpoint=http://x,pollInterval=not-a-duration")
+	assert.Error(t, err)
+
+	_, err = parseRemoteSamplerArg("endpoint=http://x,initialSamplingRate=not-a-float")
+	assert.Error(t, err)
+
+	_, err = parseRemoteSamplerArg("endpoint=http://x,initialSamplingRate=2.0")
+	assert.ErrorIs(t, err, errGreaterThanOneTraceIDRatio)
+
+	_, err = parseRemoteSamplerArg("endpoint=http://x,bogusKey=1")
+	assert.Error(t, err, "an unknown key should fail to parse")
+}
+
+func TestNewRemoteSamplerRequiresEndpoint(t *testing.T) {
+	_, err := newRemoteSampler(RemoteSamplerConfig{})
+	assert.Error(t, err)
+}
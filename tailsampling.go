@@ -0,0 +1,74 @@
+package telemetry
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// TailSamplingProcessor wraps another sdktrace.SpanProcessor (typically one built with
+// sdktrace.NewBatchSpanProcessor), passing every OnStart through but forwarding a span's OnEnd
+// to it only when keep approves the ended span. This is how decisions that can only be made at response time - minimum
+// latency, error status - are implemented: the TracerProvider's sampler records every span
+// (e.g. via ForceableSampler wrapping an AlwaysSample-ish base), and TailSamplingProcessor acts
+// as the per-request buffer that decides which of those recorded spans actually get exported.
+type TailSamplingProcessor struct {
+	next sdktrace.SpanProcessor
+	keep func(s sdktrace.ReadOnlySpan) bool
+}
+
+// NewTailSamplingProcessor returns a TailSamplingProcessor that forwards to next only the spans
+// for which keep returns true.
+func NewTailSamplingProcessor(next sdktrace.SpanProcessor, keep func(s sdktrace.ReadOnlySpan) bool) *TailSamplingProcessor {
+	return &TailSamplingProcessor{next: next, keep: keep}
+}
+
+func (p *TailSamplingProcessor) OnStart(ctx context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(ctx, s)
+}
+
+func (p *TailSamplingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if p.keep(s) {
+		p.next.OnEnd(s)
+	}
+}
+
+func (p *TailSamplingProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p *TailSamplingProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+// MinLatencyKeep returns a TailSamplingProcessor keep function that retains spans whose duration
+// is at least min.
+func MinLatencyKeep(min time.Duration) func(s sdktrace.ReadOnlySpan) bool {
+	return func(s sdktrace.ReadOnlySpan) bool {
+		return s.EndTime().Sub(s.StartTime()) >= min
+	}
+}
+
+// ErrorStatusKeep returns a TailSamplingProcessor keep function that retains spans whose status
+// was set to codes.Error, which NewHandlerWithConfig does for every 5xx response. This is how
+// "always sample error responses" is implemented: as a keep func rather than a Filter, since the
+// response status isn't known until after the handler has already run. See Filter.
+func ErrorStatusKeep() func(s sdktrace.ReadOnlySpan) bool {
+	return func(s sdktrace.ReadOnlySpan) bool {
+		return s.Status().Code == codes.Error
+	}
+}
+
+// AnyKeep combines multiple keep functions, retaining a span if any of them would.
+func AnyKeep(keeps ...func(s sdktrace.ReadOnlySpan) bool) func(s sdktrace.ReadOnlySpan) bool {
+	return func(s sdktrace.ReadOnlySpan) bool {
+		for _, keep := range keeps {
+			if keep(s) {
+				return true
+			}
+		}
+		return false
+	}
+}
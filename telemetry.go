@@ -2,14 +2,12 @@ package telemetry
 
 import (
 	"context"
-	"net/http"
+	"errors"
 	"os"
 	"strconv"
 
 	"github.com/getlantern/golog"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
 	"go.opentelemetry.io/otel/propagation"
 
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -25,26 +23,57 @@ var log = golog.LoggerFor("telemetry")
 // For example:
 // OTEL_TRACES_SAMPLER=traceidratio OTEL_TRACES_SAMPLER_ARG=0.001
 func EnableOTELTracing(ctx context.Context) func(context.Context) error {
-	log.Debug("Enabling OTEL tracing")
 	err := sampleRate()
 	if err != nil {
 		return func(ctx context.Context) error { return nil }
 	}
-	exp, err := otlptrace.New(ctx, otlptracehttp.NewClient())
+	return EnableOTELTracingWithOptions(ctx, Options{})
+}
+
+// EnableOTELTracingWithSampler behaves like EnableOTELTracing but uses sampler instead of one
+// derived from OTEL_TRACES_SAMPLER/OTEL_TRACES_SAMPLER_ARG, letting callers configure sampling
+// programmatically (e.g. via SamplerFromConfig) for configurations that need more than an env var
+// can express, such as a "remote" sampler with a caller-provided InitialSampler.
+func EnableOTELTracingWithSampler(ctx context.Context, sampler sdktrace.Sampler) func(context.Context) error {
+	return EnableOTELTracingWithOptions(ctx, Options{Sampler: sampler})
+}
+
+// EnableOTELTracingWithOptions behaves like EnableOTELTracing but takes an Options struct so
+// callers can pick the OTLP transport (http or grpc), exporter TLS/headers/timeout/compression,
+// batch span processor tuning, resource attributes, and sampler programmatically instead of
+// solely through the OTEL_* environment variables.
+func EnableOTELTracingWithOptions(ctx context.Context, opts Options) func(context.Context) error {
+	log.Debug("Enabling OTEL tracing")
+
+	exp, err := newTraceExporter(ctx, opts)
 	if err != nil {
 		log.Errorf("telemetry failed to initialize exporter: %w", err)
 		return func(ctx context.Context) error { return nil }
 	}
-	envSampler, err := samplerFromEnv()
+
+	sampler, err := resolveSampler(opts.Sampler)
 	if err != nil {
 		log.Errorf("telemetry failed to initialize sampler: %w", err)
 		return func(ctx context.Context) error { return nil }
 	}
 
-	// Create a new tracer provider with a batch span processor and the otlp exporter.
+	res, err := newResource(opts.ResourceAttributes)
+	if err != nil {
+		log.Errorf("telemetry failed to initialize resource: %w", err)
+		return func(ctx context.Context) error { return nil }
+	}
+
+	// Create a new tracer provider with a batch span processor and the otlp exporter, optionally
+	// wrapped in a TailSamplingProcessor so response-time-only decisions can still drop spans
+	// that were recorded but shouldn't be exported.
+	var sp sdktrace.SpanProcessor = sdktrace.NewBatchSpanProcessor(exp, opts.Batch.asProcessorOptions()...)
+	if opts.TailSamplingKeep != nil {
+		sp = NewTailSamplingProcessor(sp, opts.TailSamplingKeep)
+	}
 	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithSampler(ForceableSampler(envSampler)),
-		sdktrace.WithBatcher(exp),
+		sdktrace.WithSampler(ForceableSampler(sampler)),
+		sdktrace.WithResource(res),
+		sdktrace.WithSpanProcessor(sp),
 	)
 
 	// Set the Tracer Provider and the W3C Trace Context propagator as globals
@@ -57,9 +86,24 @@ func EnableOTELTracing(ctx context.Context) func(context.Context) error {
 			propagation.Baggage{},
 		),
 	)
+	// samplerCloser releases resources the sampler holds beyond the span it returns, such as
+	// newRemoteSampler's background polling goroutine. It's captured on the unwrapped sampler
+	// value, before ForceableSampler wraps it, since that wrapper doesn't expose it itself.
+	samplerCloser, hasSamplerCloser := closerOf(sampler)
 	return func(ctx context.Context) error {
-		tp.Shutdown(ctx)
-		return exp.Shutdown(ctx)
+		var errs []error
+		if hasSamplerCloser {
+			if err := samplerCloser.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if err := tp.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+		if err := exp.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+		return errors.Join(errs...)
 	}
 }
 
@@ -85,15 +129,28 @@ func AlwaysSample(ctx context.Context) context.Context {
 	return context.WithValue(ctx, forceSample, true)
 }
 
+// ForceDrop returns a context that will never be sampled by the sampler, taking precedence over
+// AlwaysSample.
+func ForceDrop(ctx context.Context) context.Context {
+	return context.WithValue(ctx, forceDrop, true)
+}
+
 type forceType string
 
-const forceSample = forceType("force-sample")
+const (
+	forceSample = forceType("force-sample")
+	forceDrop   = forceType("force-drop")
+)
 
 type forceable struct {
 	wrapped sdktrace.Sampler
 }
 
 func (os forceable) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	if val, ok := p.ParentContext.Value(forceDrop).(bool); ok && val {
+		log.Debugf("Overriding sampler to never sample for trace %s", p.Name)
+		return sdktrace.NeverSample().ShouldSample(p)
+	}
 	if val, ok := p.ParentContext.Value(forceSample).(bool); ok && val {
 		log.Debugf("Overriding sampler to always sample for trace %s", p.Name)
 		return sdktrace.AlwaysSample().ShouldSample(p)
@@ -111,40 +168,3 @@ func (os forceable) Description() string {
 func ForceableSampler(wrapped sdktrace.Sampler) sdktrace.Sampler {
 	return forceable{wrapped: wrapped}
 }
-
-type ForceSampleFilter interface {
-	ForceSample(r *http.Request) bool
-}
-
-type requestFilterFunc func(r *http.Request) bool
-
-func (rf requestFilterFunc) ForceSample(r *http.Request) bool {
-	return rf(r)
-}
-
-// AlwaysSampleHTTPHeader returns a ForceSampleFilter that will always sample requests that
-// have the specified header set to the specified value.
-func AlwaysSampleHTTPHeader(header string, value string) ForceSampleFilter {
-	return requestFilterFunc(func(r *http.Request) bool {
-		return r.Header.Get(header) == value
-	})
-}
-
-// AlwaysSampleHeaderHandler wraps the passed handler and always samples requests that
-// have the specified header set to the specified value.
-func AlwaysSampleHeaderHandler(header string, value string, handler http.Handler) http.Handler {
-	return NewHandler(handler, AlwaysSampleHTTPHeader(header, value))
-}
-
-// NewHandler wraps the passed handler and allows callers to set rules for things that should
-// always be sampled.
-func NewHandler(handler http.Handler, filters ...ForceSampleFilter) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		for _, filter := range filters {
-			if filter.ForceSample(r) {
-				r = r.WithContext(AlwaysSample(r.Context()))
-			}
-		}
-		handler.ServeHTTP(w, r)
-	})
-}
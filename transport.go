@@ -0,0 +1,84 @@
+package telemetry
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TransportConfig configures the span/propagation instrumentation NewTransport wraps around an
+// outbound http.RoundTripper. The zero value uses the global TracerProvider and
+// TextMapPropagator.
+type TransportConfig struct {
+	// Tracer starts the client span for each request. Defaults to the global TracerProvider's
+	// "github.com/getlantern/telemetry" tracer.
+	Tracer trace.Tracer
+	// Propagator injects the active trace context/baggage into outbound request headers.
+	// Defaults to the global TextMapPropagator.
+	Propagator propagation.TextMapPropagator
+}
+
+func (cfg TransportConfig) tracer() trace.Tracer {
+	if cfg.Tracer != nil {
+		return cfg.Tracer
+	}
+	return otel.Tracer(instrumentationName)
+}
+
+func (cfg TransportConfig) propagator() propagation.TextMapPropagator {
+	if cfg.Propagator != nil {
+		return cfg.Propagator
+	}
+	return otel.GetTextMapPropagator()
+}
+
+// NewTransport wraps rt (http.DefaultTransport if nil) with OTEL HTTP client instrumentation: it
+// starts a client span for each outbound request, records HTTP semantic-convention attributes
+// and the response status, and injects the active trace context/baggage via cfg.Propagator so
+// the receiving service can continue the trace.
+func NewTransport(rt http.RoundTripper, cfg TransportConfig) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &instrumentedTransport{
+		wrapped:    rt,
+		tracer:     cfg.tracer(),
+		propagator: cfg.propagator(),
+	}
+}
+
+type instrumentedTransport struct {
+	wrapped    http.RoundTripper
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+func (t *instrumentedTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.Start(r.Context(), r.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.method", r.Method),
+			attribute.String("http.url", r.URL.String()),
+		),
+	)
+	defer span.End()
+
+	r = r.Clone(ctx)
+	t.propagator.Inject(ctx, propagation.HeaderCarrier(r.Header))
+
+	resp, err := t.wrapped.RoundTrip(r)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+	return resp, nil
+}